@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultConfigExtensions is the order in which defaultConfigPath looks for
+// "~/.gomcc.*" when no -f flag is given.
+var defaultConfigExtensions = []string{"json", "yaml", "yml", "toml"}
+
+// defaultConfigPath returns the first "~/.gomcc.{json,yaml,yml,toml}" that
+// exists, in that order, or "" if none exist or the home directory can't be
+// determined.
+func defaultConfigPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	for _, ext := range defaultConfigExtensions {
+		candidate := filepath.Join(homeDir, ".gomcc."+ext)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// splitTags parses a comma-separated tag list into a cleaned-up slice.
+func splitTags(tags string) []string {
+	if tags == "" {
+		return nil
+	}
+	parts := strings.Split(tags, ",")
+	var result []string
+	for _, part := range parts {
+		trimmed := strings.TrimSpace(part)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// equalStringSlices compares two string slices for equality.
+func equalStringSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// selectApps resolves which apps a listing/run command should operate on,
+// based on the (mutually exclusive, in this precedence order) app name,
+// group tag, and tag-filter selectors.
+func selectApps(config *Config, appName, groupTag string, tags []string) ([]App, error) {
+	if appName != "" {
+		app := config.GetApp(appName)
+		if app == nil {
+			return nil, fmt.Errorf("app '%s' not found", appName)
+		}
+		return []App{*app}, nil
+	}
+	if groupTag != "" {
+		return config.GetAppsByTag(groupTag), nil
+	}
+	if len(tags) > 0 {
+		return config.GetAppsByTags(tags), nil
+	}
+	return config.Apps, nil
+}
+
+// printAppList prints the executable app names, one per line.
+func printAppList(apps []App) {
+	for _, app := range apps {
+		if app.Type == TypeExecutable {
+			fmt.Println(app.Name)
+		}
+	}
+}
+
+// printAppListDetailed prints full details for each executable app,
+// including its resolved command and dependencies.
+func printAppListDetailed(executor *Executor, apps []App) {
+	fmt.Println("Executable Apps:")
+	fmt.Println("================")
+	count := 0
+	for _, app := range apps {
+		if app.Type != TypeExecutable {
+			continue
+		}
+		count++
+		fmt.Printf("\nName: %s\n", app.Name)
+		if len(app.Tags) > 0 {
+			fmt.Printf("  Tags: %s\n", strings.Join(app.Tags, ", "))
+		}
+
+		cmd, cmdArgs, err := executor.ResolveCommand(&app)
+		if err != nil {
+			fmt.Printf("  Command: %s %s (error: %v)\n", app.Command, strings.Join(app.Args, " "), err)
+		} else if cmd != app.Command || !equalStringSlices(cmdArgs, app.Args) {
+			fmt.Printf("  Default Command: %s %s\n", app.Command, strings.Join(app.Args, " "))
+			fmt.Printf("  Resolved Command: %s %s\n", cmd, strings.Join(cmdArgs, " "))
+		} else {
+			fmt.Printf("  Command: %s %s\n", cmd, strings.Join(cmdArgs, " "))
+		}
+
+		if len(app.Requires) > 0 {
+			fmt.Printf("  Requires: %s\n", strings.Join(app.Requires, ", "))
+		}
+
+		if len(app.Dependencies) > 0 {
+			fmt.Printf("  Dependencies:\n")
+			for depName, action := range app.Dependencies {
+				fmt.Printf("    - %s\n", depName)
+				if action.OnSuccess != "" {
+					fmt.Printf("        on_success: %s\n", action.OnSuccess)
+				}
+				if action.OnFailure != "" {
+					fmt.Printf("        on_failure: %s\n", action.OnFailure)
+				}
+			}
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("\nNo executable apps found.")
+	} else {
+		fmt.Printf("\nTotal: %d executable app(s)\n", count)
+	}
+}
+
+// runCheckApp executes a single check app by name and prints its result.
+func runCheckApp(executor *Executor, config *Config, name string) error {
+	app := config.GetApp(name)
+	if app == nil {
+		return fmt.Errorf("app '%s' not found", name)
+	}
+	if app.Type != TypeCheck {
+		return fmt.Errorf("app '%s' is not a check type", name)
+	}
+	return executor.PrintCheckResult(app)
+}
+
+// executeApps runs every executable app in apps, skipping check apps and
+// apps whose dependencies aren't satisfied, using up to parallel concurrent
+// workers, and returns the resulting success/failure/skipped counts.
+func executeApps(executor *Executor, apps []App, parallel int) (successCount, failureCount, skippedCount int, err error) {
+	return executor.ExecuteAll(apps, parallel)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// newCheckCommand builds the "check" subcommand, which executes and prints
+// the result of a single check app.
+func newCheckCommand() *Command {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	configPath := fs.String("f", defaultConfigPath(), "Path to the configuration file")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s check <name>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Execute and print the result of a check app.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "check",
+		Description: "Execute and print the result of a check app",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				fs.Usage()
+				os.Exit(1)
+			}
+			if *configPath == "" {
+				return fmt.Errorf("config file path not specified and no default found")
+			}
+
+			config, err := LoadConfig(*configPath)
+			if err != nil {
+				return fmt.Errorf("loading configuration: %w", err)
+			}
+			executor := NewExecutor(config, LogNormal, false)
+
+			if err := runCheckApp(executor, config, args[0]); err != nil {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// newCompletionCommand builds the "completion" subcommand, which prints a
+// shell completion script to stdout.
+func newCompletionCommand() *Command {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	configPath := fs.String("f", defaultConfigPath(), "Config file used to complete tag and app names")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s completion <bash|zsh|fish>\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Print a shell completion script to stdout.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s completion bash > /etc/bash_completion.d/gomcc\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s completion zsh > \"${fpath[1]}/_gomcc\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s completion fish > ~/.config/fish/completions/gomcc.fish\n", os.Args[0])
+	}
+
+	return &Command{
+		Name:        "completion",
+		Description: "Generate shell completion scripts",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) != 1 {
+				fs.Usage()
+				os.Exit(1)
+			}
+
+			// The config is optional: completion still works for flags and
+			// subcommands without it, just not for tag/app names.
+			var config *Config
+			if *configPath != "" {
+				if c, err := LoadConfig(*configPath); err == nil {
+					config = c
+				}
+			}
+
+			return writeCompletionScript(os.Stdout, args[0], config)
+		},
+	}
+}
+
+// writeCompletionScript writes a shell completion script for shell to w. If
+// config is non-nil, the script also completes tag values and app names.
+func writeCompletionScript(w io.Writer, shell string, config *Config) error {
+	switch shell {
+	case "bash":
+		return writeBashCompletion(w, config)
+	case "zsh":
+		return writeZshCompletion(w, config)
+	case "fish":
+		return writeFishCompletion(w, config)
+	default:
+		return fmt.Errorf("unsupported shell %q (want bash, zsh, or fish)", shell)
+	}
+}
+
+// executableAppNames returns the sorted names of config's executable apps.
+func executableAppNames(config *Config) []string {
+	return appNamesByType(config, TypeExecutable)
+}
+
+// checkAppNames returns the sorted names of config's check apps.
+func checkAppNames(config *Config) []string {
+	return appNamesByType(config, TypeCheck)
+}
+
+func appNamesByType(config *Config, appType AppType) []string {
+	if config == nil {
+		return nil
+	}
+	var names []string
+	for _, app := range config.Apps {
+		if app.Type == appType {
+			names = append(names, app.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func allTags(config *Config) []string {
+	if config == nil {
+		return nil
+	}
+	return config.AllTags()
+}
+
+func writeBashCompletion(w io.Writer, config *Config) error {
+	tags := strings.Join(allTags(config), " ")
+	execApps := strings.Join(executableAppNames(config), " ")
+	checkApps := strings.Join(checkAppNames(config), " ")
+
+	_, err := fmt.Fprintf(w, `# bash completion for gomcc
+_gomcc() {
+    local cur prev
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    local commands="run list check version completion ctl"
+    local tags="%s"
+    local exec_apps="%s"
+    local check_apps="%s"
+
+    case "$prev" in
+        -g|-tag)
+            COMPREPLY=( $(compgen -W "$tags" -- "$cur") )
+            return 0
+            ;;
+        -c)
+            COMPREPLY=( $(compgen -W "$exec_apps" -- "$cur") )
+            return 0
+            ;;
+        -e)
+            COMPREPLY=( $(compgen -W "$check_apps" -- "$cur") )
+            return 0
+            ;;
+        completion)
+            COMPREPLY=( $(compgen -W "bash zsh fish" -- "$cur") )
+            return 0
+            ;;
+        ctl)
+            COMPREPLY=( $(compgen -W "list status stop restart logs" -- "$cur") )
+            return 0
+            ;;
+        check)
+            COMPREPLY=( $(compgen -W "$check_apps" -- "$cur") )
+            return 0
+            ;;
+    esac
+
+    if [[ ${COMP_CWORD} -eq 1 ]]; then
+        COMPREPLY=( $(compgen -W "$commands" -- "$cur") )
+        return 0
+    fi
+
+    case "${COMP_WORDS[1]}" in
+        run|list)
+            COMPREPLY=( $(compgen -W "$exec_apps" -- "$cur") )
+            ;;
+    esac
+}
+complete -F _gomcc gomcc
+`, tags, execApps, checkApps)
+	return err
+}
+
+func writeZshCompletion(w io.Writer, config *Config) error {
+	tags := strings.Join(allTags(config), " ")
+	execApps := strings.Join(executableAppNames(config), " ")
+	checkApps := strings.Join(checkAppNames(config), " ")
+
+	_, err := fmt.Fprintf(w, `#compdef gomcc
+
+_gomcc() {
+    local -a commands
+    commands=(run list check version completion ctl)
+
+    if (( CURRENT == 2 )); then
+        _describe 'command' commands
+        return
+    fi
+
+    case "${words[2]}" in
+        run|list)
+            _arguments \
+                '-g[launch/list apps with this tag]:tag:(%s)' \
+                '-tag[comma-separated tags to filter apps]:tags:(%s)' \
+                '*:app:(%s)'
+            ;;
+        check)
+            _arguments '*:check app:(%s)'
+            ;;
+        completion)
+            _arguments '*:shell:(bash zsh fish)'
+            ;;
+        ctl)
+            _arguments '*:action:(list status stop restart logs)'
+            ;;
+    esac
+}
+
+_gomcc
+`, tags, tags, execApps, checkApps)
+	return err
+}
+
+func writeFishCompletion(w io.Writer, config *Config) error {
+	tags := strings.Join(allTags(config), " ")
+	execApps := strings.Join(executableAppNames(config), " ")
+	checkApps := strings.Join(checkAppNames(config), " ")
+
+	_, err := fmt.Fprintf(w, `# fish completion for gomcc
+complete -c gomcc -f
+complete -c gomcc -n '__fish_use_subcommand' -a run -d 'Launch apps'
+complete -c gomcc -n '__fish_use_subcommand' -a list -d 'List executable apps'
+complete -c gomcc -n '__fish_use_subcommand' -a check -d 'Execute and print result of a check app'
+complete -c gomcc -n '__fish_use_subcommand' -a version -d 'Print the gomcc version'
+complete -c gomcc -n '__fish_use_subcommand' -a completion -d 'Generate shell completion scripts'
+complete -c gomcc -n '__fish_use_subcommand' -a ctl -d 'Control a running supervisor'
+
+complete -c gomcc -n '__fish_seen_subcommand_from run list' -a '%s'
+complete -c gomcc -n '__fish_seen_subcommand_from check' -a '%s'
+complete -c gomcc -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'
+complete -c gomcc -n '__fish_seen_subcommand_from ctl' -a 'list status stop restart logs'
+complete -c gomcc -n '__fish_seen_subcommand_from run list' -l tag -a '%s'
+complete -c gomcc -n '__fish_seen_subcommand_from run list' -s g -a '%s'
+`, execApps, checkApps, tags, tags)
+	return err
+}
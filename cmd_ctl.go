@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+)
+
+// newCtlCommand builds the "ctl" subcommand, which talks to a running
+// "run -supervise" supervisor over its control socket.
+func newCtlCommand() *Command {
+	fs := flag.NewFlagSet("ctl", flag.ExitOnError)
+	socketPath := fs.String("socket", defaultSocketPath(), "Unix socket path of the running supervisor")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s ctl <list|status|stop|restart|logs> [name]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Control a supervisor started with '%s run -supervise'.\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s ctl list\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s ctl status web\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s ctl restart web\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s ctl logs web\n", os.Args[0])
+	}
+
+	return &Command{
+		Name:        "ctl",
+		Description: "Control a running supervisor (list/status/stop/restart/logs)",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if len(args) < 1 {
+				fs.Usage()
+				os.Exit(1)
+			}
+
+			req := controlRequest{Cmd: args[0]}
+			if len(args) > 1 {
+				req.Name = args[1]
+			}
+			switch req.Cmd {
+			case "status", "stop", "restart", "logs":
+				if req.Name == "" {
+					return fmt.Errorf("%q requires an app name", req.Cmd)
+				}
+			}
+
+			resp, err := sendControlRequest(*socketPath, req)
+			if err != nil {
+				return err
+			}
+			if !resp.OK {
+				return fmt.Errorf("%s", resp.Error)
+			}
+
+			printControlResponse(req.Cmd, resp)
+			return nil
+		},
+	}
+}
+
+// sendControlRequest connects to socketPath, sends req, and decodes the
+// response.
+func sendControlRequest(socketPath string, req controlRequest) (*controlResponse, error) {
+	if socketPath == "" {
+		return nil, fmt.Errorf("could not determine the supervisor socket path; pass -socket explicitly")
+	}
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to supervisor at %s: %w", socketPath, err)
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var resp controlResponse
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	return &resp, nil
+}
+
+// printControlResponse renders a successful controlResponse to stdout.
+func printControlResponse(cmd string, resp *controlResponse) {
+	switch cmd {
+	case "logs":
+		fmt.Println(resp.Logs)
+	case "list", "status":
+		for _, a := range resp.Apps {
+			fmt.Printf("%-20s %-8s pid=%-8d restarts=%-4d started=%s\n",
+				a.Name, a.Status, a.PID, a.Restarts, a.StartedAt.Format("2006-01-02 15:04:05"))
+		}
+	default:
+		fmt.Println("OK")
+	}
+}
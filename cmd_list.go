@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// newListCommand builds the "list" subcommand, which prints known apps.
+func newListCommand() *Command {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	configPath := fs.String("f", defaultConfigPath(), "Path to the configuration file")
+	tags := fs.String("tag", "", "Comma-separated list of tags to filter apps")
+	groupTag := fs.String("g", "", "List all apps with a specific tag")
+	detailed := fs.Bool("detailed", false, "Show detailed information for each app")
+	params := make(paramFlag)
+	fs.Var(params, "param", "Set a parameter as KEY=VALUE for ${VAR} substitution (repeatable)")
+	paramsFile := fs.String("params-file", "", "Load parameters from a KEY=VALUE, JSON, or YAML file")
+	ignoreMissingParams := fs.Bool("ignore-missing-params", false, "Expand undefined ${VAR} references to empty instead of erroring")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s list [options] [app]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "List executable apps, one name per line by default.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+	}
+
+	return &Command{
+		Name:        "list",
+		Description: "List executable apps",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *configPath == "" {
+				return fmt.Errorf("config file path not specified and no default found")
+			}
+
+			config, err := LoadConfig(*configPath)
+			if err != nil {
+				return fmt.Errorf("loading configuration: %w", err)
+			}
+			executor := NewExecutor(config, LogNormal, false)
+
+			resolvedParams, err := resolveParams(params, *paramsFile)
+			if err != nil {
+				return err
+			}
+			executor.SetParams(resolvedParams, *ignoreMissingParams)
+
+			appName := ""
+			if len(args) > 0 {
+				appName = args[0]
+			}
+
+			apps, err := selectApps(config, appName, *groupTag, splitTags(*tags))
+			if err != nil {
+				return err
+			}
+
+			if *detailed {
+				printAppListDetailed(executor, apps)
+			} else {
+				printAppList(apps)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// newRunCommand builds the "run" subcommand, which launches apps.
+func newRunCommand() *Command {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := fs.String("f", defaultConfigPath(), "Path to the configuration file")
+	tags := fs.String("tag", "", "Comma-separated list of tags to filter apps")
+	groupTag := fs.String("g", "", "Launch all apps with a specific tag")
+	verbose := fs.Bool("v", false, "Enable verbose mode")
+	debug := fs.Bool("d", false, "Enable debug mode (implies verbose)")
+	dryRun := fs.Bool("dry-run", false, "Don't actually execute, just show what would run")
+	parallel := fs.Int("parallel", 1, "Number of apps to launch concurrently")
+	fs.IntVar(parallel, "p", 1, "Alias for -parallel")
+	params := make(paramFlag)
+	fs.Var(params, "param", "Set a parameter as KEY=VALUE for ${VAR} substitution (repeatable)")
+	paramsFile := fs.String("params-file", "", "Load parameters from a KEY=VALUE, JSON, or YAML file")
+	ignoreMissingParams := fs.Bool("ignore-missing-params", false, "Expand undefined ${VAR} references to empty instead of erroring")
+	supervise := fs.Bool("supervise", false, "Stay running as a supervisor that keeps apps alive and serves a control API")
+	socketPath := fs.String("socket", defaultSocketPath(), "Unix socket path for the supervisor control API (with -supervise)")
+	logDir := fs.String("log-dir", defaultLogDir(), "Directory for per-app supervised log files (with -supervise)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s run [options] [app]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Launch one or more apps, resolving dependencies first.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s run                    # Launch all apps\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run myapp              # Launch only 'myapp'\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -g production      # Launch all apps tagged 'production'\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -tag web,backend   # Launch apps with web or backend tags\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -dry-run           # Dry-run mode\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -p 4               # Launch up to 4 apps concurrently\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -param host=web-1  # Substitute ${host} in commands and args\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s run -supervise         # Stay running, restart crashed apps, serve 'ctl'\n", os.Args[0])
+	}
+
+	return &Command{
+		Name:        "run",
+		Description: "Launch apps (optionally filtered by name or tag)",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			if *configPath == "" {
+				return fmt.Errorf("config file path not specified and no default found")
+			}
+
+			config, err := LoadConfig(*configPath)
+			if err != nil {
+				return fmt.Errorf("loading configuration: %w", err)
+			}
+
+			logLevel := LogNormal
+			switch {
+			case *debug:
+				logLevel = LogDebug
+			case *verbose:
+				logLevel = LogVerbose
+			}
+			executor := NewExecutor(config, logLevel, *dryRun)
+
+			resolvedParams, err := resolveParams(params, *paramsFile)
+			if err != nil {
+				return err
+			}
+			executor.SetParams(resolvedParams, *ignoreMissingParams)
+
+			appName := ""
+			if len(args) > 0 {
+				appName = args[0]
+			}
+
+			apps, err := selectApps(config, appName, *groupTag, splitTags(*tags))
+			if err != nil {
+				return err
+			}
+
+			executor.log(LogVerbose, "[VERBOSE] Found %d app(s) to process", len(apps))
+
+			if *supervise {
+				if *socketPath == "" || *logDir == "" {
+					return fmt.Errorf("could not determine a home directory for the supervisor socket/log dir; pass -socket and -log-dir explicitly")
+				}
+				supervisor := NewSupervisor(executor, *logDir, *socketPath)
+				return supervisor.Run(apps)
+			}
+
+			successCount, failureCount, skippedCount, err := executeApps(executor, apps, *parallel)
+			if err != nil {
+				return err
+			}
+
+			if *verbose || *debug || *dryRun {
+				fmt.Println()
+				fmt.Printf("Execution Summary:\n")
+				fmt.Printf("  Successfully launched: %d\n", successCount)
+				fmt.Printf("  Failed to launch:      %d\n", failureCount)
+				fmt.Printf("  Skipped:               %d\n", skippedCount)
+			}
+
+			if failureCount > 0 {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+}
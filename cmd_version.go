@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+// Version is the gomcc release version.
+const Version = "0.1.0"
+
+// newVersionCommand builds the "version" subcommand.
+func newVersionCommand() *Command {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+
+	return &Command{
+		Name:        "version",
+		Description: "Print the gomcc version",
+		FlagSet:     fs,
+		Exec: func(ctx context.Context, args []string) error {
+			fmt.Printf("gomcc version %s\n", Version)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Command represents a single gomcc subcommand: it owns its own flag set,
+// its own usage text, and the function that carries out the work.
+type Command struct {
+	Name        string
+	Description string
+	FlagSet     *flag.FlagSet
+	Exec        func(ctx context.Context, args []string) error
+}
+
+// Runner dispatches os.Args to the registered subcommands. New subcommands
+// (e.g. "graph", "validate") can be added by registering them here without
+// touching main.
+type Runner struct {
+	commands []*Command
+}
+
+// NewRunner creates a Runner with no registered commands.
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Register adds a subcommand to the runner.
+func (r *Runner) Register(cmd *Command) {
+	r.commands = append(r.commands, cmd)
+}
+
+// Lookup returns the registered command with the given name, or nil.
+func (r *Runner) Lookup(name string) *Command {
+	for _, cmd := range r.commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// Usage prints the top-level usage listing every registered subcommand.
+func (r *Runner) Usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [arguments]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "gomcc - A flexible CLI launcher for managing application dependencies\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	for _, cmd := range r.commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", cmd.Name, cmd.Description)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for details on a specific command.\n", os.Args[0])
+}
+
+// Run looks up args[0] as a subcommand name, parses the remaining args with
+// that subcommand's flag set, and executes it.
+func (r *Runner) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		r.Usage()
+		os.Exit(0)
+	}
+
+	cmd := r.Lookup(args[0])
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		r.Usage()
+		os.Exit(1)
+	}
+
+	if err := cmd.FlagSet.Parse(args[1:]); err != nil {
+		os.Exit(1)
+	}
+
+	return cmd.Exec(ctx, cmd.FlagSet.Args())
+}
@@ -4,6 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 )
 
 // AppType represents the type of application
@@ -16,44 +23,96 @@ const (
 
 // DependencyAction defines what to execute based on dependency result
 type DependencyAction struct {
-	OnSuccess string `json:"on_success,omitempty"`
-	OnFailure string `json:"on_failure,omitempty"`
+	OnSuccess string `json:"on_success,omitempty" yaml:"on_success,omitempty" toml:"on_success,omitempty"`
+	OnFailure string `json:"on_failure,omitempty" yaml:"on_failure,omitempty" toml:"on_failure,omitempty"`
 }
 
 // App represents an application configuration
 type App struct {
-	Name         string                      `json:"name"`
-	Type         AppType                     `json:"type"`
-	Command      string                      `json:"command"`
-	Args         []string                    `json:"args,omitempty"`
-	Tags         []string                    `json:"tags,omitempty"`
-	Dependencies map[string]DependencyAction `json:"dependencies,omitempty"`
+	Name         string                      `json:"name" yaml:"name" toml:"name"`
+	Type         AppType                     `json:"type" yaml:"type" toml:"type"`
+	Command      string                      `json:"command" yaml:"command" toml:"command"`
+	Args         []string                    `json:"args,omitempty" yaml:"args,omitempty" toml:"args,omitempty"`
+	Tags         []string                    `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty"`
+	Dependencies map[string]DependencyAction `json:"dependencies,omitempty" yaml:"dependencies,omitempty" toml:"dependencies,omitempty"`
+	// Timeout is a Go time.Duration string (e.g. "30s") after which the
+	// app's process (or check command, when run to resolve dependencies) is
+	// killed; the "check" subcommand runs a check without it. Empty means no
+	// timeout.
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	// Requires lists other apps that must be launched before this one is
+	// scheduled. This only orders launches: it does not wait for the
+	// required apps to exit, let alone succeed, since ExecuteApp launches
+	// apps and returns without waiting for them to finish. Gating on an
+	// app's outcome is what the check-based branching in Dependencies is
+	// for.
+	Requires []string `json:"requires,omitempty" yaml:"requires,omitempty" toml:"requires,omitempty"`
+	// Env holds environment variables merged into the child process's
+	// environment. Values may themselves reference ${VAR} params, including
+	// ones defined once in Config.Env.
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty" toml:"env,omitempty"`
+	// Restart configures whether a supervised app (see "run -supervise") is
+	// relaunched after it exits. Nil means "no" (never restart).
+	Restart *RestartPolicy `json:"restart,omitempty" yaml:"restart,omitempty" toml:"restart,omitempty"`
+}
+
+// RestartPolicy controls how the supervisor reacts to a supervised app
+// exiting.
+type RestartPolicy struct {
+	// Mode is one of "no", "on-failure", or "always".
+	Mode string `json:"mode" yaml:"mode" toml:"mode"`
+	// MaxRestarts caps the number of restarts; 0 means unlimited.
+	MaxRestarts int `json:"max_restarts,omitempty" yaml:"max_restarts,omitempty" toml:"max_restarts,omitempty"`
+	// Backoff is a Go time.Duration string to wait before each restart.
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty" toml:"backoff,omitempty"`
 }
 
 // Config represents the entire configuration file
 type Config struct {
-	Apps []App `json:"apps"`
+	Apps []App `json:"apps" yaml:"apps" toml:"apps"`
+	// Env holds parameter values shared across all apps, referenced from
+	// commands, args, dependency actions, or per-app Env as ${VAR}.
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty" toml:"env,omitempty"`
 }
 
-// LoadConfig loads the configuration from a JSON file
+// LoadConfig loads the configuration from a JSON, YAML, or TOML file,
+// dispatching on the file's extension.
 func LoadConfig(path string) (*Config, error) {
-	file, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open config file: %w", err)
 	}
-	defer file.Close()
 
 	var config Config
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&config); err != nil {
-		return nil, fmt.Errorf("failed to decode config file: %w", err)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json", "":
+		if err := json.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode config file: %w", err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode config file: %w", err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(data, &config); err != nil {
+			return nil, fmt.Errorf("failed to decode config file: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q", ext)
 	}
 
-	// Validate configuration
+	// Validate configuration, uniformly regardless of source format.
 	if err := validateConfig(&config); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	// Build the execute-order dependency graph up front so cycles are
+	// reported at load time rather than surfacing mid-run.
+	requires, dependents, order := buildRequireGraph(config.Apps)
+	if _, err := topoLevels(order, requires, dependents); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	return &config, nil
 }
 
@@ -77,15 +136,41 @@ func validateConfig(config *Config) error {
 		if app.Command == "" {
 			return fmt.Errorf("app '%s' has empty command", app.Name)
 		}
+
+		// Validate the timeout, if any, is a well-formed duration
+		if app.Timeout != "" {
+			if _, err := time.ParseDuration(app.Timeout); err != nil {
+				return fmt.Errorf("app '%s' has invalid timeout %q: %w", app.Name, app.Timeout, err)
+			}
+		}
+
+		// Validate the restart policy, if any
+		if app.Restart != nil {
+			switch app.Restart.Mode {
+			case "no", "on-failure", "always":
+			default:
+				return fmt.Errorf("app '%s' has invalid restart mode %q", app.Name, app.Restart.Mode)
+			}
+			if app.Restart.Backoff != "" {
+				if _, err := time.ParseDuration(app.Restart.Backoff); err != nil {
+					return fmt.Errorf("app '%s' has invalid restart backoff %q: %w", app.Name, app.Restart.Backoff, err)
+				}
+			}
+		}
 	}
 
-	// Validate dependencies exist
+	// Validate dependencies and requires reference existing apps
 	for _, app := range config.Apps {
 		for depName := range app.Dependencies {
 			if !names[depName] {
 				return fmt.Errorf("app '%s' has dependency on non-existent app '%s'", app.Name, depName)
 			}
 		}
+		for _, reqName := range app.Requires {
+			if !names[reqName] {
+				return fmt.Errorf("app '%s' requires non-existent app '%s'", app.Name, reqName)
+			}
+		}
 	}
 
 	return nil
@@ -115,6 +200,22 @@ func (c *Config) GetAppsByTag(tag string) []App {
 	return result
 }
 
+// AllTags returns the unique set of tags used across all apps, sorted.
+func (c *Config) AllTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, app := range c.Apps {
+		for _, tag := range app.Tags {
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
 // GetAppsByTags returns all apps that have at least one of the given tags
 func (c *Config) GetAppsByTags(tags []string) []App {
 	if len(tags) == 0 {
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestLoadConfigFormats asserts that equivalent JSON, YAML, and TOML config
+// fixtures all decode to the same Config.
+func TestLoadConfigFormats(t *testing.T) {
+	paths := []string{
+		"testdata/config.json",
+		"testdata/config.yaml",
+		"testdata/config.toml",
+	}
+
+	var want *Config
+	for _, path := range paths {
+		got, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig(%q) returned error: %v", path, err)
+		}
+
+		if want == nil {
+			want = got
+			continue
+		}
+
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("LoadConfig(%q) = %+v, want %+v", path, got, want)
+		}
+	}
+}
+
+func TestLoadConfigUnsupportedExtension(t *testing.T) {
+	if _, err := LoadConfig("testdata/config.ini"); err == nil {
+		t.Fatal("expected an error for an unsupported extension, got nil")
+	}
+}
+
+func TestConfigAllTags(t *testing.T) {
+	config, err := LoadConfig("testdata/config.json")
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	got := config.AllTags()
+	want := []string{"db", "production", "web"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AllTags() = %v, want %v", got, want)
+	}
+}
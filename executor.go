@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // LogLevel represents the verbosity level
@@ -19,10 +24,14 @@ const (
 
 // Executor handles the execution of apps
 type Executor struct {
-	config     *Config
-	logLevel   LogLevel
-	dryRun     bool
-	checkCache map[string]bool // Cache for check results
+	config              *Config
+	logLevel            LogLevel
+	dryRun              bool
+	cacheMu             sync.Mutex
+	checkCache          map[string]bool // Cache for check results, guarded by cacheMu
+	checkGroup          singleflight.Group
+	params              map[string]string
+	ignoreMissingParams bool
 }
 
 // NewExecutor creates a new executor
@@ -35,6 +44,58 @@ func NewExecutor(config *Config, logLevel LogLevel, dryRun bool) *Executor {
 	}
 }
 
+// SetParams configures the CLI-provided parameter values used for "${VAR}"
+// substitution in commands, args, and dependency actions. If ignoreMissing
+// is false, a reference to a variable undefined by params, Config.Env, or
+// the app's own Env is an error.
+func (e *Executor) SetParams(params map[string]string, ignoreMissing bool) {
+	e.params = params
+	e.ignoreMissingParams = ignoreMissing
+}
+
+// effectiveParams merges Config.Env, app.Env, and the CLI-supplied params
+// (highest precedence) into a single lookup map, then resolves any "${VAR}"
+// references within the values themselves against that same map.
+func (e *Executor) effectiveParams(app *App) (map[string]string, error) {
+	combined := make(map[string]string)
+	for k, v := range e.config.Env {
+		combined[k] = v
+	}
+	for k, v := range app.Env {
+		combined[k] = v
+	}
+	for k, v := range e.params {
+		combined[k] = v
+	}
+
+	resolved := make(map[string]string, len(combined))
+	for k, v := range combined {
+		sv, err := substitute(v, combined, e.ignoreMissingParams)
+		if err != nil {
+			return nil, fmt.Errorf("resolving parameter %q: %w", k, err)
+		}
+		resolved[k] = sv
+	}
+	return resolved, nil
+}
+
+// resolvedEnv returns app.Env's resolved "KEY=VALUE" pairs for merging into
+// a child process's environment.
+func (e *Executor) resolvedEnv(app *App) ([]string, error) {
+	if len(app.Env) == 0 {
+		return nil, nil
+	}
+	params, err := e.effectiveParams(app)
+	if err != nil {
+		return nil, err
+	}
+	env := make([]string, 0, len(app.Env))
+	for k := range app.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, params[k]))
+	}
+	return env, nil
+}
+
 // log prints a message at the specified level
 func (e *Executor) log(level LogLevel, format string, args ...interface{}) {
 	if e.logLevel >= level {
@@ -42,37 +103,78 @@ func (e *Executor) log(level LogLevel, format string, args ...interface{}) {
 	}
 }
 
-// ExecuteCheck runs a check app and returns true if successful
+// cachedCheckResult returns the cached result for a check, if any.
+func (e *Executor) cachedCheckResult(name string) (bool, bool) {
+	e.cacheMu.Lock()
+	defer e.cacheMu.Unlock()
+	result, exists := e.checkCache[name]
+	return result, exists
+}
+
+// storeCheckResult records the result of a check in the cache.
+func (e *Executor) storeCheckResult(name string, success bool) {
+	e.cacheMu.Lock()
+	e.checkCache[name] = success
+	e.cacheMu.Unlock()
+}
+
+// ExecuteCheck runs a check app and returns true if successful. Concurrent
+// callers checking the same app are deduplicated so the check runs at most
+// once.
 func (e *Executor) ExecuteCheck(app *App) (bool, error) {
 	if app.Type != TypeCheck {
 		return false, fmt.Errorf("app '%s' is not a check type", app.Name)
 	}
 
-	// Check cache first
-	if result, exists := e.checkCache[app.Name]; exists {
+	if result, exists := e.cachedCheckResult(app.Name); exists {
 		e.log(LogDebug, "[DEBUG] Using cached result for check '%s': %v", app.Name, result)
 		return result, nil
 	}
 
+	v, err, _ := e.checkGroup.Do(app.Name, func() (interface{}, error) {
+		return e.runCheck(app)
+	})
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+// runCheck performs the actual check execution and caches the result.
+func (e *Executor) runCheck(app *App) (bool, error) {
+	if result, exists := e.cachedCheckResult(app.Name); exists {
+		return result, nil
+	}
+
 	e.log(LogVerbose, "[VERBOSE] Executing check: %s", app.Name)
 	e.log(LogDebug, "[DEBUG] Check command: %s %v", app.Command, app.Args)
 
 	if e.dryRun {
 		e.log(LogNormal, "[DRY-RUN] Would execute check: %s %s", app.Command, strings.Join(app.Args, " "))
 		// In dry-run mode, assume success
-		e.checkCache[app.Name] = true
+		e.storeCheckResult(app.Name, true)
 		return true, nil
 	}
 
-	cmd := exec.Command(app.Command, app.Args...)
+	ctx := context.Background()
+	if app.Timeout != "" {
+		d, err := time.ParseDuration(app.Timeout)
+		if err != nil {
+			return false, fmt.Errorf("invalid timeout %q for check '%s': %w", app.Timeout, app.Name, err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, app.Command, app.Args...)
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 
 	err := cmd.Run()
 	success := err == nil
 
-	// Cache the result
-	e.checkCache[app.Name] = success
+	e.storeCheckResult(app.Name, success)
 
 	if success {
 		e.log(LogVerbose, "[VERBOSE] Check '%s' succeeded", app.Name)
@@ -117,11 +219,17 @@ func (e *Executor) ResolveCommand(app *App) (string, []string, error) {
 	return e.resolveCommand(app)
 }
 
-// resolveCommand determines the actual command to execute based on dependencies
+// resolveCommand determines the actual command to execute based on
+// dependencies, substituting "${VAR}" parameters along the way.
 func (e *Executor) resolveCommand(app *App) (string, []string, error) {
+	params, err := e.effectiveParams(app)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve parameters for app '%s': %w", app.Name, err)
+	}
+
 	if len(app.Dependencies) == 0 {
 		// No dependencies, use the default command
-		return app.Command, app.Args, nil
+		return e.substituteCommand(app.Command, app.Args, params)
 	}
 
 	e.log(LogDebug, "[DEBUG] Resolving command for app '%s' with dependencies", app.Name)
@@ -144,20 +252,38 @@ func (e *Executor) resolveCommand(app *App) (string, []string, error) {
 
 		if success && action.OnSuccess != "" {
 			e.log(LogDebug, "[DEBUG] Dependency '%s' succeeded, using on_success command", depName)
-			return e.parseCommand(action.OnSuccess)
+			return e.parseCommand(action.OnSuccess, params)
 		} else if !success && action.OnFailure != "" {
 			e.log(LogDebug, "[DEBUG] Dependency '%s' failed, using on_failure command", depName)
-			return e.parseCommand(action.OnFailure)
+			return e.parseCommand(action.OnFailure, params)
 		}
 	}
 
 	// If no dependency action matched, use default command
 	e.log(LogDebug, "[DEBUG] No dependency action matched, using default command")
-	return app.Command, app.Args, nil
+	return e.substituteCommand(app.Command, app.Args, params)
 }
 
-// parseCommand splits a command string into command and arguments
-func (e *Executor) parseCommand(cmdStr string) (string, []string, error) {
+// substituteCommand applies "${VAR}" substitution to a command and its args.
+func (e *Executor) substituteCommand(cmd string, args []string, params map[string]string) (string, []string, error) {
+	sc, err := substitute(cmd, params, e.ignoreMissingParams)
+	if err != nil {
+		return "", nil, err
+	}
+	sargs, err := substituteAll(args, params, e.ignoreMissingParams)
+	if err != nil {
+		return "", nil, err
+	}
+	return sc, sargs, nil
+}
+
+// parseCommand substitutes "${VAR}" params into cmdStr, then splits it into
+// a command and arguments.
+func (e *Executor) parseCommand(cmdStr string, params map[string]string) (string, []string, error) {
+	cmdStr, err := substitute(cmdStr, params, e.ignoreMissingParams)
+	if err != nil {
+		return "", nil, err
+	}
 	parts := strings.Fields(cmdStr)
 	if len(parts) == 0 {
 		return "", nil, fmt.Errorf("empty command string")
@@ -179,6 +305,11 @@ func (e *Executor) ExecuteApp(app *App) error {
 		return fmt.Errorf("failed to resolve command for app '%s': %w", app.Name, err)
 	}
 
+	env, err := e.resolvedEnv(app)
+	if err != nil {
+		return fmt.Errorf("failed to resolve environment for app '%s': %w", app.Name, err)
+	}
+
 	fullCmd := fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
 	e.log(LogDebug, "[DEBUG] Resolved command: %s", fullCmd)
 
@@ -189,8 +320,21 @@ func (e *Executor) ExecuteApp(app *App) error {
 
 	e.log(LogNormal, "Launching app '%s': %s", app.Name, fullCmd)
 
+	ctx := context.Background()
+	var cancel context.CancelFunc
+	if app.Timeout != "" {
+		d, err := time.ParseDuration(app.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %q for app '%s': %w", app.Timeout, app.Name, err)
+		}
+		ctx, cancel = context.WithTimeout(ctx, d)
+	}
+
 	// Create command
-	execCmd := exec.Command(cmd, args...)
+	execCmd := exec.CommandContext(ctx, cmd, args...)
+	if len(env) > 0 {
+		execCmd.Env = append(os.Environ(), env...)
+	}
 
 	// Detach the process so it continues running after we exit
 	execCmd.SysProcAttr = &syscall.SysProcAttr{
@@ -199,11 +343,23 @@ func (e *Executor) ExecuteApp(app *App) error {
 
 	// Start the process
 	if err := execCmd.Start(); err != nil {
+		if cancel != nil {
+			cancel()
+		}
 		return fmt.Errorf("failed to start app '%s': %w", app.Name, err)
 	}
 
 	e.log(LogVerbose, "[VERBOSE] Successfully launched app '%s' with PID %d", app.Name, execCmd.Process.Pid)
 
+	if cancel != nil {
+		// Reap the process once it exits or its timeout fires, and release
+		// the timer backing the context.
+		go func() {
+			execCmd.Wait()
+			cancel()
+		}()
+	}
+
 	// Don't wait for the process to finish - let it run independently
 	return nil
 }
@@ -247,3 +403,115 @@ func (e *Executor) CanExecuteApp(app *App) (bool, error) {
 
 	return canExecute, nil
 }
+
+// ExecuteAll runs every executable app in apps in topological order:
+// apps whose Requires have already been launched run concurrently (bounded
+// by parallel workers), and the next level only starts once every app in
+// the current one has been launched. Requires is launch-order only - since
+// ExecuteApp doesn't wait for a process to exit, it does not guarantee a
+// required app has finished, let alone succeeded, before its dependents
+// start; use the check-based Dependencies to gate on an app's outcome.
+// Check apps, and apps whose Dependencies aren't satisfied, are skipped.
+// It returns the resulting success/failure/skipped counts, or an error if
+// apps contains a dependency cycle.
+func (e *Executor) ExecuteAll(apps []App, parallel int) (successCount, failureCount, skippedCount int, err error) {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	requires, dependents, order := buildRequireGraph(apps)
+	levels, err := topoLevels(order, requires, dependents)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	byName := make(map[string]App, len(apps))
+	for _, app := range apps {
+		byName[app.Name] = app
+	}
+
+	status := make(map[string]string, len(apps)) // name -> "success"/"failure"/"skipped" (of launching, not of the process running to completion)
+	var statusMu sync.Mutex
+
+	setStatus := func(name, s string) {
+		statusMu.Lock()
+		status[name] = s
+		statusMu.Unlock()
+	}
+
+	sem := make(chan struct{}, parallel)
+
+	for _, level := range levels {
+		var wg sync.WaitGroup
+
+		for _, name := range level {
+			app := byName[name]
+
+			if app.Type == TypeCheck {
+				e.log(LogDebug, "[DEBUG] Skipping check app '%s' in execution", app.Name)
+				setStatus(app.Name, "skipped")
+				continue
+			}
+
+			if blocker, blocked := e.firstUnmetRequirement(requires[app.Name], status, &statusMu); blocked {
+				e.log(LogVerbose, "[VERBOSE] Skipping app '%s' - required app '%s' was not launched successfully", app.Name, blocker)
+				setStatus(app.Name, "skipped")
+				continue
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(app App) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				canExecute, cerr := e.CanExecuteApp(&app)
+				switch {
+				case cerr != nil:
+					fmt.Fprintf(os.Stderr, "Error checking dependencies for app '%s': %v\n", app.Name, cerr)
+					setStatus(app.Name, "failure")
+				case !canExecute:
+					e.log(LogVerbose, "[VERBOSE] Skipping app '%s' - dependencies not satisfied", app.Name)
+					setStatus(app.Name, "skipped")
+				default:
+					if err := e.ExecuteApp(&app); err != nil {
+						fmt.Fprintf(os.Stderr, "Error executing app '%s': %v\n", app.Name, err)
+						setStatus(app.Name, "failure")
+					} else {
+						setStatus(app.Name, "success")
+					}
+				}
+			}(app)
+		}
+
+		// Cross-level ordering must be preserved, so the next level can't
+		// start until every app in this one has been launched or skipped.
+		wg.Wait()
+	}
+
+	for _, s := range status {
+		switch s {
+		case "success":
+			successCount++
+		case "failure":
+			failureCount++
+		case "skipped":
+			skippedCount++
+		}
+	}
+
+	return successCount, failureCount, skippedCount, nil
+}
+
+// firstUnmetRequirement returns the name of the first required app that
+// hasn't (yet) been successfully launched, if any.
+func (e *Executor) firstUnmetRequirement(reqs []string, status map[string]string, mu *sync.Mutex) (string, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, dep := range reqs {
+		if status[dep] != "success" {
+			return dep, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// countingCheck returns a check App that appends a line to path each time
+// its command actually runs, so tests can tell how many times it executed.
+func countingCheck(name, path string) App {
+	return App{
+		Name:    name,
+		Type:    TypeCheck,
+		Command: "sh",
+		Args:    []string{"-c", fmt.Sprintf("echo ran >> %s", path)},
+	}
+}
+
+// trackingCheck returns a check App whose command brackets its execution
+// with "start"/"end" markers in path, so tests can reconstruct how many
+// ran concurrently.
+func trackingCheck(name, path string) App {
+	return App{
+		Name:    name,
+		Type:    TypeCheck,
+		Command: "sh",
+		Args:    []string{"-c", fmt.Sprintf("echo start >> %s; sleep 0.15; echo end >> %s", path, path)},
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// TestExecuteAllDedupsSharedCheck verifies that several apps in the same
+// topo level depending on the same check app only run that check once:
+// concurrent ExecuteCheck callers are deduplicated by the singleflight
+// group, and the cache avoids repeating it once the first call lands.
+func TestExecuteAllDedupsSharedCheck(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ran.log")
+
+	check := countingCheck("shared-check", path)
+
+	var apps []App
+	apps = append(apps, check)
+	for i := 0; i < 8; i++ {
+		apps = append(apps, App{
+			Name:    fmt.Sprintf("app-%d", i),
+			Type:    TypeExecutable,
+			Command: "true",
+			Dependencies: map[string]DependencyAction{
+				"shared-check": {OnSuccess: "true"},
+			},
+		})
+	}
+
+	executor := NewExecutor(&Config{Apps: apps}, LogNormal, false)
+	successCount, failureCount, _, err := executor.ExecuteAll(apps, 4)
+	if err != nil {
+		t.Fatalf("ExecuteAll returned error: %v", err)
+	}
+	if failureCount != 0 {
+		t.Errorf("failureCount = %d, want 0", failureCount)
+	}
+	if successCount != 8 {
+		t.Errorf("successCount = %d, want 8", successCount)
+	}
+
+	if lines := readLines(t, path); len(lines) != 1 {
+		t.Errorf("shared check ran %d times, want exactly 1: %v", len(lines), lines)
+	}
+}
+
+// TestExecuteAllRespectsParallelBound runs enough independent apps with
+// slow checks that, without a concurrency bound, they'd all overlap. It
+// reconstructs the start/end intervals recorded by each check and asserts
+// no more than `parallel` were ever in flight at once.
+func TestExecuteAllRespectsParallelBound(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "overlap.log")
+
+	const (
+		n        = 6
+		parallel = 2
+	)
+
+	var apps []App
+	for i := 0; i < n; i++ {
+		checkName := fmt.Sprintf("check-%d", i)
+		apps = append(apps, trackingCheck(checkName, path))
+		apps = append(apps, App{
+			Name:    fmt.Sprintf("app-%d", i),
+			Type:    TypeExecutable,
+			Command: "true",
+			Dependencies: map[string]DependencyAction{
+				checkName: {OnSuccess: "true"},
+			},
+		})
+	}
+
+	executor := NewExecutor(&Config{Apps: apps}, LogNormal, false)
+	if _, _, _, err := executor.ExecuteAll(apps, parallel); err != nil {
+		t.Fatalf("ExecuteAll returned error: %v", err)
+	}
+
+	current, peak := 0, 0
+	for _, line := range readLines(t, path) {
+		switch line {
+		case "start":
+			current++
+			if current > peak {
+				peak = current
+			}
+		case "end":
+			current--
+		}
+	}
+
+	if peak > parallel {
+		t.Errorf("peak concurrent checks = %d, want <= %d", peak, parallel)
+	}
+}
+
+// TestExecuteAllHandlesRequiresChain verifies that a Requires chain across
+// topo levels launches every app without error, with b only considered for
+// launch once a's level has finished (see buildRequireGraph/topoLevels in
+// graph_test.go for the pure level math this builds on).
+func TestExecuteAllHandlesRequiresChain(t *testing.T) {
+	apps := []App{
+		{Name: "a", Type: TypeExecutable, Command: "true"},
+		{Name: "b", Type: TypeExecutable, Command: "true", Requires: []string{"a"}},
+		{Name: "c", Type: TypeExecutable, Command: "true", Requires: []string{"b"}},
+	}
+
+	executor := NewExecutor(&Config{Apps: apps}, LogNormal, false)
+	successCount, failureCount, _, err := executor.ExecuteAll(apps, 2)
+	if err != nil {
+		t.Fatalf("ExecuteAll returned error: %v", err)
+	}
+	if failureCount != 0 || successCount != 3 {
+		t.Errorf("successCount=%d failureCount=%d, want 3/0", successCount, failureCount)
+	}
+}
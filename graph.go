@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// buildRequireGraph builds the execute-order dependency graph over apps from
+// each app's Requires list. Requires entries naming an app outside this set
+// are ignored: they're assumed to belong to a different run and are treated
+// as already satisfied.
+func buildRequireGraph(apps []App) (requires, dependents map[string][]string, order []string) {
+	names := make(map[string]bool, len(apps))
+	order = make([]string, 0, len(apps))
+	for _, app := range apps {
+		names[app.Name] = true
+		order = append(order, app.Name)
+	}
+
+	requires = make(map[string][]string)
+	dependents = make(map[string][]string)
+	for _, app := range apps {
+		for _, dep := range app.Requires {
+			if !names[dep] {
+				continue
+			}
+			requires[app.Name] = append(requires[app.Name], dep)
+			dependents[dep] = append(dependents[dep], app.Name)
+		}
+	}
+
+	return requires, dependents, order
+}
+
+// topoLevels groups order into levels using Kahn's algorithm: every app in
+// level i can run concurrently once every app in a level below i has
+// completed. It returns an error naming the cycle if the graph isn't a DAG.
+func topoLevels(order []string, requires, dependents map[string][]string) ([][]string, error) {
+	inDegree := make(map[string]int, len(order))
+	for _, name := range order {
+		inDegree[name] = len(requires[name])
+	}
+
+	visited := make(map[string]bool, len(order))
+	var levels [][]string
+
+	for len(visited) < len(order) {
+		var level []string
+		for _, name := range order {
+			if !visited[name] && inDegree[name] == 0 {
+				level = append(level, name)
+			}
+		}
+		if len(level) == 0 {
+			return nil, fmt.Errorf("dependency cycle detected: %s", findCycle(order, requires))
+		}
+		for _, name := range level {
+			visited[name] = true
+			for _, dependent := range dependents[name] {
+				inDegree[dependent]--
+			}
+		}
+		levels = append(levels, level)
+	}
+
+	return levels, nil
+}
+
+// findCycle locates a cycle in the requires graph for error reporting, once
+// topoLevels has already determined one exists.
+func findCycle(order []string, requires map[string][]string) string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	color := make(map[string]int, len(order))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		color[name] = inProgress
+		path = append(path, name)
+		for _, dep := range requires[name] {
+			switch color[dep] {
+			case inProgress:
+				for i, n := range path {
+					if n == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = done
+		return nil
+	}
+
+	for _, name := range order {
+		if color[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return strings.Join(cycle, " -> ")
+			}
+		}
+	}
+	return "(unknown)"
+}
@@ -0,0 +1,50 @@
+package main
+
+import "testing"
+
+func TestTopoLevelsOrdersByLevel(t *testing.T) {
+	apps := []App{
+		{Name: "a"},
+		{Name: "b", Requires: []string{"a"}},
+		{Name: "c", Requires: []string{"a"}},
+		{Name: "d", Requires: []string{"b", "c"}},
+	}
+
+	requires, dependents, order := buildRequireGraph(apps)
+	levels, err := topoLevels(order, requires, dependents)
+	if err != nil {
+		t.Fatalf("topoLevels returned error: %v", err)
+	}
+
+	want := [][]string{{"a"}, {"b", "c"}, {"d"}}
+	if len(levels) != len(want) {
+		t.Fatalf("got %d levels, want %d: %v", len(levels), len(want), levels)
+	}
+	for i, level := range levels {
+		if len(level) != len(want[i]) {
+			t.Errorf("level %d = %v, want %v", i, level, want[i])
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, name := range level {
+			seen[name] = true
+		}
+		for _, name := range want[i] {
+			if !seen[name] {
+				t.Errorf("level %d = %v, want %v", i, level, want[i])
+			}
+		}
+	}
+}
+
+func TestTopoLevelsDetectsCycle(t *testing.T) {
+	apps := []App{
+		{Name: "a", Requires: []string{"b"}},
+		{Name: "b", Requires: []string{"a"}},
+	}
+
+	requires, dependents, order := buildRequireGraph(apps)
+	if _, err := topoLevels(order, requires, dependents); err == nil {
+		t.Fatal("expected a cycle error, got nil")
+	}
+}
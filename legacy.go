@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CLI represents the legacy root-level flag interface. It is kept as a
+// backward-compatible alias for the "run"/"list"/"check" subcommands for one
+// release; new usage should prefer the subcommands.
+type CLI struct {
+	configPath       string
+	tags             string
+	verbose          bool
+	debug            bool
+	dryRun           bool
+	appName          string
+	groupTag         string
+	checkApp         string
+	listApps         bool
+	listAppsDetailed bool
+}
+
+// ParseArgs parses command-line arguments.
+func (c *CLI) ParseArgs() {
+	flag.StringVar(&c.configPath, "f", defaultConfigPath(), "Path to the configuration file (JSON, YAML, or TOML)")
+	flag.StringVar(&c.tags, "t", "", "Comma-separated list of tags to filter apps")
+	flag.BoolVar(&c.verbose, "v", false, "Enable verbose mode")
+	flag.BoolVar(&c.debug, "d", false, "Enable debug mode (implies verbose)")
+	flag.BoolVar(&c.dryRun, "r", false, "Enable dry-run mode (don't actually execute)")
+	flag.StringVar(&c.appName, "c", "", "Launch a specific app by name")
+	flag.StringVar(&c.groupTag, "g", "", "Launch all apps with a specific tag")
+	flag.StringVar(&c.checkApp, "e", "", "Execute and print result of a check app")
+	flag.BoolVar(&c.listApps, "l", false, "List executable app names (one per line)")
+	flag.BoolVar(&c.listAppsDetailed, "L", false, "List all executable apps with detailed information")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "gomcc - A flexible CLI launcher for managing application dependencies\n\n")
+		fmt.Fprintf(os.Stderr, "These root-level flags are a backward-compatible alias for the \"run\"/\n")
+		fmt.Fprintf(os.Stderr, "\"list\"/\"check\" subcommands and will be removed in a future release.\n")
+		fmt.Fprintf(os.Stderr, "Run '%s' with no arguments to see the subcommand list.\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s                                   # Launch all apps (uses ~/.gomcc.{json,yaml,yml,toml})\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -l                                # List executable app names\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -L                                # List executable apps with details\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -f config.json                    # Launch all apps with specific config\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -t web,backend                    # Launch apps with web or backend tags\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -c myapp                          # Launch only 'myapp'\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -g production                     # Launch all apps tagged 'production'\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -e checkapp                       # Test a check app\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -v                                # Launch with verbose logging\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -r                                # Dry-run mode\n", os.Args[0])
+	}
+
+	flag.Parse()
+
+	// Check if any action is specified
+	hasAction := c.listApps || c.listAppsDetailed || c.checkApp != "" || c.appName != "" || c.groupTag != "" || len(flag.Args()) > 0
+
+	// If no action specified and no other arguments, show usage
+	if !hasAction && flag.NFlag() == 0 {
+		flag.Usage()
+		os.Exit(0)
+	}
+
+	// Validate config file path
+	if c.configPath == "" {
+		fmt.Fprintf(os.Stderr, "Error: config file path not specified and no default found\n\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Debug mode implies verbose
+	if c.debug {
+		c.verbose = true
+	}
+}
+
+// GetLogLevel returns the appropriate log level based on flags.
+func (c *CLI) GetLogLevel() LogLevel {
+	if c.debug {
+		return LogDebug
+	}
+	if c.verbose {
+		return LogVerbose
+	}
+	return LogNormal
+}
+
+// GetTagsList returns the list of tags as a slice.
+func (c *CLI) GetTagsList() []string {
+	return splitTags(c.tags)
+}
+
+// legacyMain implements the pre-subcommand root-level flag behavior.
+func legacyMain() {
+	cli := &CLI{}
+	cli.ParseArgs()
+
+	config, err := LoadConfig(cli.configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	executor := NewExecutor(config, cli.GetLogLevel(), cli.dryRun)
+
+	if cli.listApps || cli.listAppsDetailed {
+		apps, err := selectApps(config, cli.appName, cli.groupTag, cli.GetTagsList())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if cli.listAppsDetailed {
+			printAppListDetailed(executor, apps)
+		} else {
+			printAppList(apps)
+		}
+		os.Exit(0)
+	}
+
+	if cli.checkApp != "" {
+		if err := runCheckApp(executor, config, cli.checkApp); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	var appsToLaunch []App
+	var executionMode string
+
+	if cli.appName != "" {
+		executionMode = fmt.Sprintf("app '%s'", cli.appName)
+	} else if cli.groupTag != "" {
+		executionMode = fmt.Sprintf("apps with tag '%s'", cli.groupTag)
+	} else if tags := cli.GetTagsList(); len(tags) > 0 {
+		executionMode = fmt.Sprintf("apps with tags [%s]", strings.Join(tags, ", "))
+	} else {
+		executionMode = "all apps"
+	}
+
+	appsToLaunch, err = selectApps(config, cli.appName, cli.groupTag, cli.GetTagsList())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cli.groupTag != "" && len(appsToLaunch) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no apps found with tag '%s'\n", cli.groupTag)
+	}
+
+	executor.log(LogVerbose, "[VERBOSE] Execution mode: %s", executionMode)
+	executor.log(LogVerbose, "[VERBOSE] Found %d app(s) to process", len(appsToLaunch))
+
+	successCount, failureCount, skippedCount, err := executeApps(executor, appsToLaunch, 1)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cli.verbose || cli.dryRun {
+		fmt.Println()
+		fmt.Printf("Execution Summary:\n")
+		fmt.Printf("  Successfully launched: %d\n", successCount)
+		fmt.Printf("  Failed to launch:      %d\n", failureCount)
+		fmt.Printf("  Skipped:               %d\n", skippedCount)
+	}
+
+	if failureCount > 0 {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
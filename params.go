@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// paramPattern matches "${VAR}" references in commands, args, and
+// dependency actions.
+var paramPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// substitute replaces "${VAR}" references in s using params. If
+// ignoreMissing is false, a reference to an undefined variable is an error;
+// otherwise it expands to the empty string.
+func substitute(s string, params map[string]string, ignoreMissing bool) (string, error) {
+	var firstErr error
+	result := paramPattern.ReplaceAllStringFunc(s, func(match string) string {
+		name := paramPattern.FindStringSubmatch(match)[1]
+		if v, ok := params[name]; ok {
+			return v
+		}
+		if !ignoreMissing && firstErr == nil {
+			firstErr = fmt.Errorf("undefined parameter %q", name)
+		}
+		return ""
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return result, nil
+}
+
+// substituteAll runs substitute over each element of values.
+func substituteAll(values []string, params map[string]string, ignoreMissing bool) ([]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+	result := make([]string, len(values))
+	for i, v := range values {
+		sv, err := substitute(v, params, ignoreMissing)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = sv
+	}
+	return result, nil
+}
+
+// parseParamAssignment parses a "KEY=VALUE" --param flag value.
+func parseParamAssignment(s string) (string, string, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("invalid --param %q: expected KEY=VALUE", s)
+	}
+	return key, value, nil
+}
+
+// paramFlag implements flag.Value so "--param KEY=VALUE" can be repeated on
+// the command line, accumulating into a map.
+type paramFlag map[string]string
+
+func (p paramFlag) String() string {
+	return fmt.Sprintf("%v", map[string]string(p))
+}
+
+func (p paramFlag) Set(s string) error {
+	key, value, err := parseParamAssignment(s)
+	if err != nil {
+		return err
+	}
+	p[key] = value
+	return nil
+}
+
+// resolveParams merges a params file (lower precedence) into the
+// CLI-supplied params map (higher precedence), as used by the "run" and
+// "list" commands' "--param"/"--params-file" flags. paramsFile may be empty,
+// in which case params is returned unchanged.
+func resolveParams(params map[string]string, paramsFile string) (map[string]string, error) {
+	if paramsFile == "" {
+		return params, nil
+	}
+	fileParams, err := loadParamsFile(paramsFile)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range fileParams {
+		if _, exists := params[k]; !exists {
+			params[k] = v
+		}
+	}
+	return params, nil
+}
+
+// loadParamsFile loads parameter values from path: "KEY=VALUE" lines by
+// default, or a JSON/YAML map of strings if the extension says so.
+func loadParamsFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open params file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		var params map[string]string
+		if err := json.Unmarshal(data, &params); err != nil {
+			return nil, fmt.Errorf("failed to decode params file: %w", err)
+		}
+		return params, nil
+	case ".yaml", ".yml":
+		var params map[string]string
+		if err := yaml.Unmarshal(data, &params); err != nil {
+			return nil, fmt.Errorf("failed to decode params file: %w", err)
+		}
+		return params, nil
+	default:
+		return parseEnvLines(data)
+	}
+}
+
+// parseEnvLines parses "KEY=VALUE" lines, skipping blank lines and lines
+// starting with "#".
+func parseEnvLines(data []byte) (map[string]string, error) {
+	params := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, err := parseParamAssignment(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid params file line %q: %w", line, err)
+		}
+		params[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read params file: %w", err)
+	}
+	return params, nil
+}
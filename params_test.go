@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestSubstitute(t *testing.T) {
+	params := map[string]string{"HOST": "localhost", "PORT": "8080"}
+
+	got, err := substitute("http://${HOST}:${PORT}/", params, false)
+	if err != nil {
+		t.Fatalf("substitute returned error: %v", err)
+	}
+	if want := "http://localhost:8080/"; got != want {
+		t.Errorf("substitute() = %q, want %q", got, want)
+	}
+}
+
+func TestSubstituteMissingParam(t *testing.T) {
+	if _, err := substitute("${MISSING}", nil, false); err == nil {
+		t.Fatal("expected an error for an undefined parameter, got nil")
+	}
+
+	got, err := substitute("${MISSING}", nil, true)
+	if err != nil {
+		t.Fatalf("substitute returned error with ignoreMissing: %v", err)
+	}
+	if got != "" {
+		t.Errorf("substitute() with ignoreMissing = %q, want empty string", got)
+	}
+}
@@ -0,0 +1,489 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultSocketPath returns the default control socket path,
+// "~/.gomcc/gomcc.sock", or "" if the home directory can't be determined.
+func defaultSocketPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gomcc", "gomcc.sock")
+}
+
+// defaultLogDir returns the default supervised-log directory,
+// "~/.gomcc/logs", or "" if the home directory can't be determined.
+func defaultLogDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".gomcc", "logs")
+}
+
+// supervisedProcess tracks one app running under the supervisor.
+type supervisedProcess struct {
+	app          App
+	cmd          *exec.Cmd
+	status       string // "running", "exited", "failed", "stopped"
+	restarts     int
+	startedAt    time.Time
+	logPath      string
+	stopped      bool // explicit "ctl stop": don't auto-restart
+	forceRestart bool // explicit "ctl restart": restart regardless of policy
+}
+
+// Supervisor keeps a registry of running apps, serves the control API over
+// a Unix domain socket, and restarts crashed apps per their Restart policy.
+type Supervisor struct {
+	executor   *Executor
+	logDir     string
+	socketPath string
+
+	mu       sync.Mutex
+	registry map[string]*supervisedProcess
+
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewSupervisor creates a Supervisor that writes per-app logs under logDir
+// and serves its control API on socketPath.
+func NewSupervisor(executor *Executor, logDir, socketPath string) *Supervisor {
+	return &Supervisor{
+		executor:   executor,
+		logDir:     logDir,
+		socketPath: socketPath,
+		registry:   make(map[string]*supervisedProcess),
+	}
+}
+
+// Run starts every executable app in apps under supervision, serves the
+// control API until interrupted by SIGINT/SIGTERM, then terminates every
+// supervised process group and returns.
+func (s *Supervisor) Run(apps []App) error {
+	if err := os.MkdirAll(s.logDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	os.Remove(s.socketPath)
+
+	listener, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control socket %q: %w", s.socketPath, err)
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go s.serveControl()
+
+	for _, app := range apps {
+		if app.Type != TypeExecutable {
+			continue
+		}
+		if err := s.start(app); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting app '%s': %v\n", app.Name, err)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+
+	s.shutdown()
+	listener.Close()
+	os.Remove(s.socketPath)
+	s.wg.Wait()
+	return nil
+}
+
+// logPathFor returns the log file path for app name.
+func (s *Supervisor) logPathFor(name string) string {
+	return filepath.Join(s.logDir, name+".log")
+}
+
+// rotateLog renames an existing log file to path+".1", overwriting any
+// previous rotation.
+func rotateLog(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	return os.Rename(path, path+".1")
+}
+
+// start resolves app's command, rotates its log file, and launches it in a
+// new process group, registering it for supervision.
+func (s *Supervisor) start(app App) error {
+	cmd, args, err := s.executor.ResolveCommand(&app)
+	if err != nil {
+		return fmt.Errorf("failed to resolve command: %w", err)
+	}
+
+	env, err := s.executor.resolvedEnv(&app)
+	if err != nil {
+		return fmt.Errorf("failed to resolve environment: %w", err)
+	}
+
+	logPath := s.logPathFor(app.Name)
+	if err := rotateLog(logPath); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	execCmd := exec.Command(cmd, args...)
+	execCmd.Stdout = logFile
+	execCmd.Stderr = logFile
+	if len(env) > 0 {
+		execCmd.Env = append(os.Environ(), env...)
+	}
+	execCmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	if err := execCmd.Start(); err != nil {
+		logFile.Close()
+		return fmt.Errorf("failed to start: %w", err)
+	}
+
+	s.mu.Lock()
+	proc, existed := s.registry[app.Name]
+	if existed {
+		proc.app = app
+		proc.cmd = execCmd
+		proc.status = "running"
+		proc.startedAt = time.Now()
+		proc.logPath = logPath
+	} else {
+		proc = &supervisedProcess{
+			app:       app,
+			cmd:       execCmd,
+			status:    "running",
+			startedAt: time.Now(),
+			logPath:   logPath,
+		}
+		s.registry[app.Name] = proc
+	}
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer logFile.Close()
+		s.watch(proc)
+	}()
+
+	return nil
+}
+
+// watch waits for a supervised process to exit, then restarts it if either
+// the caller explicitly requested a restart or the app's Restart policy
+// calls for one.
+func (s *Supervisor) watch(proc *supervisedProcess) {
+	err := proc.cmd.Wait()
+
+	s.mu.Lock()
+	stopped := proc.stopped
+	switch {
+	case stopped:
+		// An explicit "ctl stop" (or supervisor shutdown) sent the signal
+		// that ended this process; don't report it as a crash.
+		proc.status = "stopped"
+	case err != nil:
+		proc.status = "failed"
+	default:
+		proc.status = "exited"
+	}
+	force := proc.forceRestart
+	proc.forceRestart = false
+	s.mu.Unlock()
+
+	if stopped {
+		return
+	}
+
+	if force {
+		if err := s.start(proc.app); err != nil {
+			fmt.Fprintf(os.Stderr, "Error restarting app '%s': %v\n", proc.app.Name, err)
+		}
+		return
+	}
+
+	if !s.shouldRestart(proc, err) {
+		return
+	}
+
+	s.mu.Lock()
+	proc.restarts++
+	s.mu.Unlock()
+
+	if backoff := restartBackoff(proc.app.Restart); backoff > 0 {
+		time.Sleep(backoff)
+	}
+
+	// A "ctl stop" or supervisor shutdown may have arrived during the backoff.
+	s.mu.Lock()
+	stopped = proc.stopped
+	s.mu.Unlock()
+	if stopped {
+		return
+	}
+
+	if err := s.start(proc.app); err != nil {
+		fmt.Fprintf(os.Stderr, "Error restarting app '%s': %v\n", proc.app.Name, err)
+	}
+}
+
+// shouldRestart reports whether proc.app's Restart policy calls for a
+// restart given exitErr, the error (if any) returned by cmd.Wait.
+func (s *Supervisor) shouldRestart(proc *supervisedProcess, exitErr error) bool {
+	policy := proc.app.Restart
+	if policy == nil || policy.Mode == "" || policy.Mode == "no" {
+		return false
+	}
+	if policy.MaxRestarts > 0 && proc.restarts >= policy.MaxRestarts {
+		return false
+	}
+	switch policy.Mode {
+	case "always":
+		return true
+	case "on-failure":
+		return exitErr != nil
+	default:
+		return false
+	}
+}
+
+// restartBackoff returns the configured restart delay for policy, or 0.
+func restartBackoff(policy *RestartPolicy) time.Duration {
+	if policy == nil || policy.Backoff == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(policy.Backoff)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// shutdown sends SIGTERM to every supervised process group.
+func (s *Supervisor) shutdown() {
+	s.mu.Lock()
+	procs := make([]*supervisedProcess, 0, len(s.registry))
+	for _, p := range s.registry {
+		procs = append(procs, p)
+		p.stopped = true
+	}
+	s.mu.Unlock()
+
+	for _, p := range procs {
+		if p.cmd.Process == nil {
+			continue
+		}
+		syscall.Kill(-p.cmd.Process.Pid, syscall.SIGTERM)
+	}
+}
+
+// controlRequest is a single line-delimited JSON request sent to the
+// supervisor's control socket.
+type controlRequest struct {
+	Cmd  string `json:"cmd"`
+	Name string `json:"name,omitempty"`
+}
+
+// controlResponse is the JSON response returned for a controlRequest.
+type controlResponse struct {
+	OK    bool        `json:"ok"`
+	Error string      `json:"error,omitempty"`
+	Apps  []appStatus `json:"apps,omitempty"`
+	Logs  string      `json:"logs,omitempty"`
+}
+
+// appStatus is the control API's view of one supervised app.
+type appStatus struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	PID       int       `json:"pid,omitempty"`
+	Restarts  int       `json:"restarts"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// serveControl accepts control connections until the listener is closed.
+func (s *Supervisor) serveControl() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn decodes a single controlRequest from conn and writes back its
+// controlResponse.
+func (s *Supervisor) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	var req controlRequest
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		json.NewEncoder(conn).Encode(controlResponse{OK: false, Error: err.Error()})
+		return
+	}
+
+	json.NewEncoder(conn).Encode(s.handleRequest(req))
+}
+
+// handleRequest dispatches a single control command.
+func (s *Supervisor) handleRequest(req controlRequest) controlResponse {
+	switch req.Cmd {
+	case "list":
+		return controlResponse{OK: true, Apps: s.listStatuses()}
+	case "status":
+		st, ok := s.statusOf(req.Name)
+		if !ok {
+			return controlResponse{OK: false, Error: fmt.Sprintf("unknown app '%s'", req.Name)}
+		}
+		return controlResponse{OK: true, Apps: []appStatus{st}}
+	case "stop":
+		if err := s.stop(req.Name); err != nil {
+			return controlResponse{OK: false, Error: err.Error()}
+		}
+		return controlResponse{OK: true}
+	case "restart":
+		if err := s.restart(req.Name); err != nil {
+			return controlResponse{OK: false, Error: err.Error()}
+		}
+		return controlResponse{OK: true}
+	case "logs":
+		logs, err := s.tailLogs(req.Name, 200)
+		if err != nil {
+			return controlResponse{OK: false, Error: err.Error()}
+		}
+		return controlResponse{OK: true, Logs: logs}
+	default:
+		return controlResponse{OK: false, Error: fmt.Sprintf("unknown command %q", req.Cmd)}
+	}
+}
+
+func toAppStatus(p *supervisedProcess) appStatus {
+	pid := 0
+	if p.cmd.Process != nil {
+		pid = p.cmd.Process.Pid
+	}
+	return appStatus{
+		Name:      p.app.Name,
+		Status:    p.status,
+		PID:       pid,
+		Restarts:  p.restarts,
+		StartedAt: p.startedAt,
+	}
+}
+
+func (s *Supervisor) listStatuses() []appStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]appStatus, 0, len(s.registry))
+	for _, p := range s.registry {
+		statuses = append(statuses, toAppStatus(p))
+	}
+	return statuses
+}
+
+func (s *Supervisor) statusOf(name string) (appStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.registry[name]
+	if !ok {
+		return appStatus{}, false
+	}
+	return toAppStatus(p), true
+}
+
+// stop marks name as explicitly stopped and sends SIGTERM to its process
+// group, preventing any further automatic restarts.
+func (s *Supervisor) stop(name string) error {
+	s.mu.Lock()
+	p, ok := s.registry[name]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("unknown app '%s'", name)
+	}
+	p.stopped = true
+	running := p.status == "running"
+	var pid int
+	if running && p.cmd.Process != nil {
+		pid = p.cmd.Process.Pid
+	}
+	s.mu.Unlock()
+
+	if !running || pid == 0 {
+		return nil
+	}
+	return syscall.Kill(-pid, syscall.SIGTERM)
+}
+
+// restart forces name to be relaunched, regardless of its Restart policy.
+func (s *Supervisor) restart(name string) error {
+	s.mu.Lock()
+	p, ok := s.registry[name]
+	if !ok {
+		s.mu.Unlock()
+		return fmt.Errorf("unknown app '%s'", name)
+	}
+	p.stopped = false
+	running := p.status == "running"
+	if running {
+		p.forceRestart = true
+	}
+	app := p.app
+	var pid int
+	if running && p.cmd.Process != nil {
+		pid = p.cmd.Process.Pid
+	}
+	s.mu.Unlock()
+
+	if running {
+		if pid == 0 {
+			return fmt.Errorf("app '%s' has no process to restart", name)
+		}
+		return syscall.Kill(-pid, syscall.SIGTERM)
+	}
+	return s.start(app)
+}
+
+// tailLogs returns the last maxLines lines of name's log file.
+func (s *Supervisor) tailLogs(name string, maxLines int) (string, error) {
+	s.mu.Lock()
+	p, ok := s.registry[name]
+	s.mu.Unlock()
+	if !ok {
+		return "", fmt.Errorf("unknown app '%s'", name)
+	}
+
+	data, err := os.ReadFile(p.logPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > maxLines {
+		lines = lines[len(lines)-maxLines:]
+	}
+	return strings.Join(lines, "\n"), nil
+}